@@ -0,0 +1,275 @@
+// Package arc implements an Adaptive Replacement Cache (ARC), a sibling
+// eviction policy to lfu.Cache that balances recency against frequency
+// instead of committing to either extreme.
+package arc
+
+import (
+	"iter"
+	"time"
+
+	"lfucache/internal/lfu"
+	"lfucache/internal/linkedlist"
+)
+
+// node is an element, which contains key, value and expiry from arc
+type node[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time // zero value means the entry never expires
+}
+
+func expired[K comparable, V any](n node[K, V]) bool {
+	return !n.expiresAt.IsZero() && time.Now().After(n.expiresAt)
+}
+
+// cacheImpl maintains two "real" lists, T1 (recent, referenced once)
+// and T2 (frequent, referenced at least twice), plus two ghost lists,
+// B1 and B2, which remember only the keys of recently-evicted T1/T2
+// entries so a later re-reference can grow or shrink p, the target
+// size of T1.
+type cacheImpl[K comparable, V any] struct {
+	capacity int
+	p        int // target size of T1
+
+	t1, t2 linkedlist.ListInterface[node[K, V]]
+	b1, b2 linkedlist.ListInterface[K]
+
+	t1Elem map[K]*linkedlist.Element[node[K, V]]
+	t2Elem map[K]*linkedlist.Element[node[K, V]]
+	b1Elem map[K]*linkedlist.Element[K]
+	b2Elem map[K]*linkedlist.Element[K]
+}
+
+// New initializes an ARC cache with the given capacity.
+func New[K comparable, V any](capacity int) lfu.Cache[K, V] {
+	if capacity < 0 {
+		panic("Capacity must be a positive integer")
+	}
+	return &cacheImpl[K, V]{
+		capacity: capacity,
+		t1:       linkedlist.NewList[node[K, V]](),
+		t2:       linkedlist.NewList[node[K, V]](),
+		b1:       linkedlist.NewList[K](),
+		b2:       linkedlist.NewList[K](),
+		t1Elem:   make(map[K]*linkedlist.Element[node[K, V]], capacity),
+		t2Elem:   make(map[K]*linkedlist.Element[node[K, V]], capacity),
+		b1Elem:   make(map[K]*linkedlist.Element[K], capacity),
+		b2Elem:   make(map[K]*linkedlist.Element[K], capacity),
+	}
+}
+
+func (c *cacheImpl[K, V]) Get(key K) (V, error) {
+	var zero V
+
+	if elem, ok := c.t1Elem[key]; ok {
+		n := elem.Value
+		c.t1.Remove(elem)
+		delete(c.t1Elem, key)
+		if expired[K, V](n) {
+			return zero, lfu.ErrKeyNotFound
+		}
+		c.t2Elem[key] = c.t2.PushFront(n)
+		return n.value, nil
+	}
+
+	if elem, ok := c.t2Elem[key]; ok {
+		n := elem.Value
+		if expired[K, V](n) {
+			c.t2.Remove(elem)
+			delete(c.t2Elem, key)
+			return zero, lfu.ErrKeyNotFound
+		}
+		c.t2.Remove(elem)
+		c.t2Elem[key] = c.t2.PushFront(n)
+		return n.value, nil
+	}
+
+	return zero, lfu.ErrKeyNotFound
+}
+
+func (c *cacheImpl[K, V]) Put(key K, value V) {
+	c.put(key, value, 0)
+}
+
+func (c *cacheImpl[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.put(key, value, ttl)
+}
+
+// replace evicts the LRU entry of T1 into B1, or of T2 into B2,
+// following the standard ARC rule: evict from T1 when T1 is larger than
+// its target p, or when |T1| == p and key is a B2 ghost hit; otherwise
+// evict from T2.
+func (c *cacheImpl[K, V]) replace(keyIsB2Ghost bool) {
+	if c.t1.Len() > 0 && (c.t1.Len() > c.p || (keyIsB2Ghost && c.t1.Len() == c.p)) {
+		n := c.t1.Remove(c.t1.Back())
+		delete(c.t1Elem, n.key)
+		c.b1Elem[n.key] = c.b1.PushFront(n.key)
+		c.trimGhost(c.b1, c.b1Elem)
+		return
+	}
+	if c.t2.Len() > 0 {
+		n := c.t2.Remove(c.t2.Back())
+		delete(c.t2Elem, n.key)
+		c.b2Elem[n.key] = c.b2.PushFront(n.key)
+		c.trimGhost(c.b2, c.b2Elem)
+	}
+}
+
+// trimGhost keeps a ghost list from growing past capacity.
+func (c *cacheImpl[K, V]) trimGhost(list linkedlist.ListInterface[K], elems map[K]*linkedlist.Element[K]) {
+	for list.Len() > c.capacity {
+		key := list.Remove(list.Back())
+		delete(elems, key)
+	}
+}
+
+func (c *cacheImpl[K, V]) put(key K, value V, ttl time.Duration) {
+	if c.capacity == 0 {
+		return
+	}
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	n := node[K, V]{key: key, value: value, expiresAt: expiresAt}
+
+	// Case I: key is already cached, in T1 or T2 - refresh and promote to T2.
+	if elem, ok := c.t1Elem[key]; ok {
+		c.t1.Remove(elem)
+		delete(c.t1Elem, key)
+		c.t2Elem[key] = c.t2.PushFront(n)
+		return
+	}
+	if elem, ok := c.t2Elem[key]; ok {
+		c.t2.Remove(elem)
+		c.t2Elem[key] = c.t2.PushFront(n)
+		return
+	}
+
+	// Case II: key is a ghost of an evicted T1 entry - grow p toward recency.
+	if elem, ok := c.b1Elem[key]; ok {
+		delta := 1
+		if c.b1.Len() > 0 {
+			if d := c.b2.Len() / c.b1.Len(); d > delta {
+				delta = d
+			}
+		}
+		c.p = min(c.p+delta, c.capacity)
+		c.replace(false)
+		c.b1.Remove(elem)
+		delete(c.b1Elem, key)
+		c.t2Elem[key] = c.t2.PushFront(n)
+		return
+	}
+
+	// Case III: key is a ghost of an evicted T2 entry - shrink p toward frequency.
+	if elem, ok := c.b2Elem[key]; ok {
+		delta := 1
+		if c.b2.Len() > 0 {
+			if d := c.b1.Len() / c.b2.Len(); d > delta {
+				delta = d
+			}
+		}
+		c.p = max(c.p-delta, 0)
+		c.replace(true)
+		c.b2.Remove(elem)
+		delete(c.b2Elem, key)
+		c.t2Elem[key] = c.t2.PushFront(n)
+		return
+	}
+
+	// Case IV: key is new to the cache entirely.
+	switch total := c.t1.Len() + c.t2.Len() + c.b1.Len() + c.b2.Len(); {
+	case c.t1.Len()+c.b1.Len() == c.capacity:
+		if c.t1.Len() < c.capacity {
+			if c.b1.Len() > 0 {
+				key := c.b1.Remove(c.b1.Back())
+				delete(c.b1Elem, key)
+			}
+			c.replace(false)
+		} else if c.t1.Len() > 0 {
+			n := c.t1.Remove(c.t1.Back())
+			delete(c.t1Elem, n.key)
+		}
+	case total >= c.capacity:
+		if total >= 2*c.capacity && c.b2.Len() > 0 {
+			key := c.b2.Remove(c.b2.Back())
+			delete(c.b2Elem, key)
+		}
+		c.replace(false)
+	}
+
+	c.t1Elem[key] = c.t1.PushFront(n)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (c *cacheImpl[K, V]) All() iter.Seq2[K, V] {
+	type pair struct {
+		key   K
+		value V
+	}
+
+	pairs := make([]pair, 0, c.t1.Len()+c.t2.Len())
+
+	it2 := c.t2.NewIteratorNext()
+	for elem, ok := it2(); ok; elem, ok = it2() {
+		if !expired[K, V](elem.Value) {
+			pairs = append(pairs, pair{key: elem.Value.key, value: elem.Value.value})
+		}
+	}
+	it1 := c.t1.NewIteratorNext()
+	for elem, ok := it1(); ok; elem, ok = it1() {
+		if !expired[K, V](elem.Value) {
+			pairs = append(pairs, pair{key: elem.Value.key, value: elem.Value.value})
+		}
+	}
+
+	return func(yield func(K, V) bool) {
+		for _, p := range pairs {
+			if !yield(p.key, p.value) {
+				return
+			}
+		}
+	}
+}
+
+func (c *cacheImpl[K, V]) Size() int {
+	return c.t1.Len() + c.t2.Len()
+}
+
+func (c *cacheImpl[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// GetKeyFrequency reports which tier key is cached in: 2 for the
+// frequent tier (T2, referenced at least twice), 1 for the recent tier
+// (T1, referenced once), or ErrKeyNotFound if key isn't cached. ARC
+// doesn't track an exact hit count the way lfu.Cache does.
+func (c *cacheImpl[K, V]) GetKeyFrequency(key K) (int, error) {
+	if _, ok := c.t2Elem[key]; ok {
+		return 2, nil
+	}
+	if _, ok := c.t1Elem[key]; ok {
+		return 1, nil
+	}
+	return -1, lfu.ErrKeyNotFound
+}
+
+// Close is a no-op: ARC starts no background goroutines.
+func (c *cacheImpl[K, V]) Close() error {
+	return nil
+}
@@ -0,0 +1,110 @@
+package lfu
+
+import (
+	"sort"
+	"time"
+
+	"lfucache/internal/linkedlist"
+)
+
+// AgingMode configures how Rescore re-buckets stored frequencies, so
+// entries that were hot in the past but are cold now don't keep
+// squatting on a high frequency forever (classic LFU's "cache
+// pollution" problem).
+type AgingMode struct {
+	period   time.Duration
+	halfLife bool
+}
+
+// WindowLFU resets every entry's frequency back to 1 every period,
+// turning the cache into a sliding-window LFU.
+func WindowLFU(period time.Duration) AgingMode {
+	return AgingMode{period: period}
+}
+
+// DecayHalfLife halves every entry's frequency every period instead of
+// resetting it outright, aging out stale hotness more gradually.
+func DecayHalfLife(period time.Duration) AgingMode {
+	return AgingMode{period: period, halfLife: true}
+}
+
+// WithAging enables periodic frequency aging using the given mode. The
+// sweep runs lazily, triggered by the first Put once period has elapsed
+// since the last one; call Rescore directly to trigger it manually.
+func WithAging[K comparable, V any](mode AgingMode) Option[K, V] {
+	return func(c *cacheImpl[K, V]) {
+		c.aging = &mode
+	}
+}
+
+// Rescore re-buckets every entry's frequency according to the
+// configured aging mode. It's a no-op if WithAging wasn't used.
+//
+// O(capacity)
+func (l *cacheImpl[K, V]) Rescore() {
+	if l.aging == nil {
+		return
+	}
+	l.rescore()
+	l.lastRescore = time.Now()
+}
+
+// maybeRescore lazily triggers Rescore from Put once period has elapsed
+// since the last rescore.
+func (l *cacheImpl[K, V]) maybeRescore() {
+	if l.aging == nil || l.aging.period <= 0 {
+		return
+	}
+	if time.Since(l.lastRescore) >= l.aging.period {
+		l.Rescore()
+	}
+}
+
+// rescore walks freqToList from highest to lowest frequency, computes
+// each entry's new frequency, and rebuilds freqToList/freqToElem/
+// keyToElem from scratch, reusing the existing linkedlist primitives.
+func (l *cacheImpl[K, V]) rescore() {
+	buckets := make(map[int]linkedlist.ListInterface[node[K, V]])
+	keyElems := make(map[K]*linkedlist.Element[node[K, V]], len(l.keyToElem))
+
+	iter1 := l.freqToList.NewIteratorPrev() // highest frequency first
+	for freqElem, ok := iter1(); ok; freqElem, ok = iter1() {
+		// Walk each bucket oldest (LRU) first and PushFront in that
+		// order, so the last one pushed - the bucket's actual MRU - ends
+		// up at the front of the rebuilt bucket too.
+		iter2 := freqElem.Value.NewIteratorPrev()
+		for n, ok2 := iter2(); ok2; n, ok2 = iter2() {
+			newFreq := 1
+			if l.aging.halfLife {
+				if newFreq = n.Value.freq / 2; newFreq < 1 {
+					newFreq = 1
+				}
+			}
+			n.Value.freq = newFreq
+
+			list, ok3 := buckets[newFreq]
+			if !ok3 {
+				list = linkedlist.NewList[node[K, V]]()
+				buckets[newFreq] = list
+			}
+			keyElems[n.Value.key] = list.PushFront(n.Value)
+		}
+	}
+
+	freqs := make([]int, 0, len(buckets))
+	for freq := range buckets {
+		freqs = append(freqs, freq)
+	}
+	sort.Ints(freqs)
+
+	l.freqToList = linkedlist.NewList[linkedlist.ListInterface[node[K, V]]]()
+	l.freqToElem = make(map[int]*linkedlist.Element[linkedlist.ListInterface[node[K, V]]], l.capacity)
+	l.minFreq = 0
+	for _, freq := range freqs {
+		l.freqToElem[freq] = l.freqToList.PushBack(buckets[freq])
+		if l.minFreq == 0 {
+			l.minFreq = freq
+		}
+	}
+	l.keyToElem = keyElems
+}
@@ -0,0 +1,89 @@
+package lfu
+
+import (
+	"iter"
+	"testing"
+)
+
+// TestRescorePreservesRecencyOrder guards against rescore flipping
+// intra-bucket recency: after Put(A), Put(B), Put(C) (all freq 1), All()
+// must list C, B, A (most-recently-used first) both before and after a
+// WindowLFU rescore collapses them back into a single freq-1 bucket.
+func TestRescorePreservesRecencyOrder(t *testing.T) {
+	c := New[string, int](3, WithAging[string, int](WindowLFU(0)))
+	c.Put("A", 1)
+	c.Put("B", 2)
+	c.Put("C", 3)
+
+	want := []string{"C", "B", "A"}
+	if got := keys(c.All()); !equal(got, want) {
+		t.Fatalf("before rescore: got %v, want %v", got, want)
+	}
+
+	c.Rescore()
+
+	if got := keys(c.All()); !equal(got, want) {
+		t.Fatalf("after rescore: got %v, want %v", got, want)
+	}
+}
+
+// TestWindowLFUResetsFrequency guards the actual point of WindowLFU: a
+// Rescore must reset entries sitting above frequency 1 back down to 1,
+// not just preserve recency order among entries that were already at 1.
+func TestWindowLFUResetsFrequency(t *testing.T) {
+	c := New[string, int](3, WithAging[string, int](WindowLFU(0)))
+	c.Put("A", 1)
+	c.Get("A") // bump A to freq 2
+	c.Put("B", 2)
+
+	c.Rescore()
+
+	if freq, err := c.GetKeyFrequency("A"); err != nil || freq != 1 {
+		t.Fatalf("GetKeyFrequency(A) = %d, %v, want 1, nil", freq, err)
+	}
+	if freq, err := c.GetKeyFrequency("B"); err != nil || freq != 1 {
+		t.Fatalf("GetKeyFrequency(B) = %d, %v, want 1, nil", freq, err)
+	}
+}
+
+// TestDecayHalfLifeHalvesFrequency guards DecayHalfLife, which was
+// otherwise completely untested: a Rescore must halve each entry's
+// frequency (rounding up to 1) rather than resetting it to 1 outright.
+func TestDecayHalfLifeHalvesFrequency(t *testing.T) {
+	c := New[string, int](3, WithAging[string, int](DecayHalfLife(0)))
+	c.Put("A", 1)
+	for i := 0; i < 6; i++ {
+		c.Get("A") // bump A to freq 7
+	}
+	c.Put("B", 2)
+	c.Get("B") // bump B to freq 2
+
+	c.Rescore()
+
+	if freq, err := c.GetKeyFrequency("A"); err != nil || freq != 3 {
+		t.Fatalf("GetKeyFrequency(A) = %d, %v, want 3 (7/2)", freq, err)
+	}
+	if freq, err := c.GetKeyFrequency("B"); err != nil || freq != 1 {
+		t.Fatalf("GetKeyFrequency(B) = %d, %v, want 1 (2/2)", freq, err)
+	}
+}
+
+func keys[V any](seq iter.Seq2[string, V]) []string {
+	var ks []string
+	for k := range seq {
+		ks = append(ks, k)
+	}
+	return ks
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
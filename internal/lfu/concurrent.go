@@ -0,0 +1,113 @@
+package lfu
+
+import (
+	"iter"
+	"sync"
+	"time"
+)
+
+// syncCache wraps a cacheImpl with a sync.RWMutex so a single Cache can
+// be shared safely across goroutines, e.g. from HTTP handlers or workers.
+type syncCache[K comparable, V any] struct {
+	mu    sync.RWMutex
+	cache *cacheImpl[K, V]
+}
+
+// NewConcurrent initializes a thread-safe cache with the given capacity.
+// Get and Put acquire the lock for writing because they mutate the
+// frequency lists; Size and Capacity only need a read lock. The
+// unexported withLocker option wires the underlying cache's background
+// janitor (see WithJanitor) into this same lock, so a janitor sweep
+// can't race a concurrent Get/Put.
+func NewConcurrent[K comparable, V any](capacity int, opts ...Option[K, V]) Cache[K, V] {
+	s := &syncCache[K, V]{}
+	s.cache = New[K, V](capacity, append([]Option[K, V]{withLocker[K, V](&s.mu)}, opts...)...)
+	return s
+}
+
+func (s *syncCache[K, V]) Get(key K) (V, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Get(key)
+}
+
+func (s *syncCache[K, V]) Put(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Put(key, value)
+}
+
+func (s *syncCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.PutWithTTL(key, value, ttl)
+}
+
+// All snapshots the cache's (key, value) pairs under the lock and yields
+// from the snapshot, so a caller iterating can't deadlock by calling Put
+// from within the loop body. It takes the write lock, not a read lock,
+// because the underlying cacheImpl.All() mutates the cache: it sweeps
+// out any expired entries it encounters as it walks.
+func (s *syncCache[K, V]) All() iter.Seq2[K, V] {
+	type pair struct {
+		key   K
+		value V
+	}
+
+	s.mu.Lock()
+	pairs := make([]pair, 0, s.cache.Size())
+	for k, v := range s.cache.All() {
+		pairs = append(pairs, pair{key: k, value: v})
+	}
+	s.mu.Unlock()
+
+	return func(yield func(K, V) bool) {
+		for _, p := range pairs {
+			if !yield(p.key, p.value) {
+				return
+			}
+		}
+	}
+}
+
+func (s *syncCache[K, V]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cache.Size()
+}
+
+func (s *syncCache[K, V]) Capacity() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cache.Capacity()
+}
+
+func (s *syncCache[K, V]) GetKeyFrequency(key K) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cache.GetKeyFrequency(key)
+}
+
+// allEntries behaves like All, but carries each entry's frequency along
+// with it - used internally by NewSharded's merge, which needs a
+// frequency to sort by without re-deriving it from live state after the
+// snapshot.
+func (s *syncCache[K, V]) allEntries() []Entry[K, V] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.snapshotEntries()
+}
+
+// Stats returns the wrapped cache's current hit, miss and eviction
+// counts under the lock, so it's safe to call concurrently with Get/Put.
+func (s *syncCache[K, V]) Stats() Stats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cache.Stats()
+}
+
+func (s *syncCache[K, V]) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Close()
+}
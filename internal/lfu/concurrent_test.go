@@ -0,0 +1,90 @@
+package lfu
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestNewConcurrentDelegation checks that NewConcurrent's forwarding
+// methods actually delegate to the wrapped cacheImpl correctly - Get,
+// Put, Size, Capacity and GetKeyFrequency, and that eviction still
+// happens once capacity is exceeded - as opposed to the race tests below,
+// which only check that forwarding doesn't race.
+func TestNewConcurrentDelegation(t *testing.T) {
+	c := NewConcurrent[string, int](2)
+	defer c.Close()
+
+	if got := c.Capacity(); got != 2 {
+		t.Fatalf("Capacity() = %d, want 2", got)
+	}
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if got := c.Size(); got != 2 {
+		t.Fatalf("Size() = %d, want 2", got)
+	}
+
+	if v, err := c.Get("a"); err != nil || v != 1 {
+		t.Fatalf("Get(a) = %v, %v, want 1, nil", v, err)
+	}
+	if freq, err := c.GetKeyFrequency("a"); err != nil || freq != 2 {
+		t.Fatalf("GetKeyFrequency(a) = %v, %v, want 2, nil", freq, err)
+	}
+
+	c.Put("c", 3) // capacity 2: evicts the LRU, which is "b"
+	if _, err := c.Get("b"); err == nil {
+		t.Fatal("expected b to have been evicted")
+	}
+	if got := c.Size(); got != 2 {
+		t.Fatalf("Size() after eviction = %d, want 2", got)
+	}
+}
+
+// TestNewConcurrentJanitorRace exercises the exact combination WithJanitor's
+// doc comment calls out: a NewConcurrent cache with a default TTL and a
+// janitor running alongside concurrent Get/Put. Run with -race; before the
+// withLocker fix this reliably raced within milliseconds.
+func TestNewConcurrentJanitorRace(t *testing.T) {
+	c := NewConcurrent[int, int](16,
+		WithDefaultTTL[int, int](time.Millisecond),
+		WithJanitor[int, int](time.Millisecond),
+	)
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				c.Put(g*1000+i, i)
+				c.Get(g*1000 + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+// TestNewConcurrentAllRace exercises concurrent All() calls, which mutate
+// the cache by sweeping expired entries as they walk it. Run with -race;
+// before taking the write lock in syncCache.All, this reliably raced.
+func TestNewConcurrentAllRace(t *testing.T) {
+	c := NewConcurrent[int, int](16, WithDefaultTTL[int, int](time.Millisecond))
+	for i := 0; i < 16; i++ {
+		c.Put(i, i)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				for range c.All() {
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -3,13 +3,13 @@ package lfu
 import (
 	"errors"
 	"iter"
+	"time"
+
 	"lfucache/internal/linkedlist"
 )
 
 var ErrKeyNotFound = errors.New("key not found")
 
-const DefaultCapacity = 5
-
 // Cache
 // O(capacity) memory
 type Cache[K comparable, V any] interface {
@@ -28,6 +28,14 @@ type Cache[K comparable, V any] interface {
 	// O(1)
 	Put(key K, value V)
 
+	// PutWithTTL behaves like Put, but the entry expires once ttl has
+	// elapsed, after which Get and All treat it as absent. A ttl <= 0 means
+	// the entry never expires. Size and GetKeyFrequency still count an
+	// expired entry until it is removed.
+	//
+	// O(1)
+	PutWithTTL(key K, value V, ttl time.Duration)
+
 	// All returns the iterator in descending order of frequency.
 	// If two or more keys have tmented")he same frequency, the most recently used key will be listed first.
 	//
@@ -49,14 +57,71 @@ type Cache[K comparable, V any] interface {
 	//
 	// O(1)
 	GetKeyFrequency(key K) (int, error)
+
+	// Close releases resources owned by the cache, such as the
+	// background janitor goroutine started by WithJanitor. It is safe to
+	// call on a cache that started no such goroutine.
+	Close() error
 }
 
 // node is an element, which contains
 // key and value from lfu
 type node[K comparable, V any] struct {
-	key   K
-	value V
-	freq  int
+	key       K
+	value     V
+	freq      int
+	expiresAt time.Time // zero value means the entry never expires
+}
+
+// Option configures optional cache behavior at construction time.
+type Option[K comparable, V any] func(*cacheImpl[K, V])
+
+// WithDefaultTTL sets the time-to-live applied to every Put call that
+// doesn't specify its own via PutWithTTL.
+func WithDefaultTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(c *cacheImpl[K, V]) {
+		c.defaultTTL = ttl
+	}
+}
+
+// WithJanitor starts a background goroutine that sweeps expired entries
+// every interval, so a cold key doesn't sit around consuming capacity
+// until it's next accessed. Stop it with Close. A cache built with
+// WithJanitor and used directly (not through NewConcurrent) still
+// requires external synchronization between the caller's own Get/Put
+// calls and the janitor, the same way it already does between two
+// caller goroutines; NewConcurrent wires the janitor into its own lock
+// so that combination is safe out of the box.
+//
+// Close itself needs no such synchronization: the goroutine never reads
+// the janitorStop field after it starts, only the channel it captured.
+func WithJanitor[K comparable, V any](interval time.Duration) Option[K, V] {
+	return func(c *cacheImpl[K, V]) {
+		c.janitorInterval = interval
+	}
+}
+
+// locker is satisfied by *sync.RWMutex. NewConcurrent sets it via the
+// unexported withLocker option so the background janitor goroutine
+// takes the same lock Get/Put already go through, instead of mutating
+// freqToList/freqToElem/keyToElem unsynchronized.
+type locker interface {
+	Lock()
+	Unlock()
+}
+
+type noopLocker struct{}
+
+func (noopLocker) Lock()   {}
+func (noopLocker) Unlock() {}
+
+// withLocker is unexported: only NewConcurrent wires a real lock in, so
+// a plain New()-constructed cache keeps needing the same external
+// synchronization its Get/Put calls already require.
+func withLocker[K comparable, V any](l locker) Option[K, V] {
+	return func(c *cacheImpl[K, V]) {
+		c.mu = l
+	}
 }
 
 // cacheImpl represents LFU cache implementation
@@ -66,24 +131,119 @@ type cacheImpl[K comparable, V any] struct {
 	keyToElem  map[K]*linkedlist.Element[node[K, V]]
 	capacity   int
 	minFreq    int
+
+	defaultTTL      time.Duration
+	janitorInterval time.Duration
+	janitorStop     chan struct{}
+
+	aging       *AgingMode
+	lastRescore time.Time
+
+	onEvict func(K, V, int)
+	onHit   func(K, V)
+	onMiss  func(K)
+
+	hits      int
+	misses    int
+	evictions int
+
+	mu locker
 }
 
-// New initializes the cache with the given capacity.
-// If no capacity is provided, the cache will use DefaultCapacity.
-func New[K comparable, V any](capacity ...int) *cacheImpl[K, V] {
-	cap := DefaultCapacity
-	if len(capacity) > 0 {
-		if cap = capacity[0]; cap < 0 {
-			panic("Capacity must be a positive integer")
-		}
+// New initializes the cache with the given capacity, applying any
+// options passed in.
+func New[K comparable, V any](capacity int, opts ...Option[K, V]) *cacheImpl[K, V] {
+	if capacity < 0 {
+		panic("Capacity must be a positive integer")
 	}
-	return &cacheImpl[K, V]{
-		freqToList: linkedlist.NewList[linkedlist.ListInterface[node[K, V]]](),                   // List of nodes with frequencies that store lists of nodes with elements
-		freqToElem: make(map[int]*linkedlist.Element[linkedlist.ListInterface[node[K, V]]], cap), // Map from frequency to node with frequency
-		keyToElem:  make(map[K]*linkedlist.Element[node[K, V]], cap),                             // Map from the key to the node with the element
-		capacity:   cap,
+	c := &cacheImpl[K, V]{
+		freqToList: linkedlist.NewList[linkedlist.ListInterface[node[K, V]]](),                        // List of nodes with frequencies that store lists of nodes with elements
+		freqToElem: make(map[int]*linkedlist.Element[linkedlist.ListInterface[node[K, V]]], capacity), // Map from frequency to node with frequency
+		keyToElem:  make(map[K]*linkedlist.Element[node[K, V]], capacity),                             // Map from the key to the node with the element
+		capacity:   capacity,
 		minFreq:    0,
+		mu:         noopLocker{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.janitorInterval > 0 {
+		c.startJanitor()
+	}
+	if c.aging != nil {
+		c.lastRescore = time.Now()
+	}
+	return c
+}
+
+// expired reports whether n's TTL has elapsed.
+func (l *cacheImpl[K, V]) expired(n node[K, V]) bool {
+	return !n.expiresAt.IsZero() && time.Now().After(n.expiresAt)
+}
+
+// removeNode removes key's node from its frequency bucket, independent
+// of whether that bucket is the current minFreq, and bumps minFreq if
+// the bucket it was the only occupant of was the minimum.
+func (l *cacheImpl[K, V]) removeNode(key K) {
+	elem := l.keyToElem[key]
+	freq := elem.Value.freq
+	list := l.freqToElem[freq]
+	list.Value.Remove(elem)
+	if list.Value.Len() == 0 {
+		l.freqToList.Remove(list)
+		delete(l.freqToElem, freq)
+		if freq == l.minFreq {
+			l.minFreq++
+		}
 	}
+	delete(l.keyToElem, key)
+}
+
+// startJanitor launches the background goroutine that periodically
+// sweeps expired entries. It must only be called once, from New.
+func (l *cacheImpl[K, V]) startJanitor() {
+	l.janitorStop = make(chan struct{})
+	stop := l.janitorStop // captured once; Close sets the field to nil, never this local
+	ticker := time.NewTicker(l.janitorInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				l.sweepExpired()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// sweepExpired removes every currently-expired entry from the cache. It
+// takes l.mu itself, rather than assuming the caller already holds it,
+// because it also runs from the janitor's own goroutine.
+func (l *cacheImpl[K, V]) sweepExpired() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var expired []K
+	for key, elem := range l.keyToElem {
+		if l.expired(elem.Value) {
+			expired = append(expired, key)
+		}
+	}
+	for _, key := range expired {
+		l.removeNode(key)
+	}
+}
+
+// Close stops the background janitor goroutine started by WithJanitor,
+// if any. It is safe to call on a cache with no janitor.
+func (l *cacheImpl[K, V]) Close() error {
+	if l.janitorStop != nil {
+		close(l.janitorStop)
+		l.janitorStop = nil
+	}
+	return nil
 }
 
 // clearNodes clears node, which contained a current element,
@@ -115,31 +275,75 @@ func (l *cacheImpl[K, V]) incrementFreq(key K) {
 }
 
 func (l *cacheImpl[K, V]) Get(key K) (V, error) {
-	if _, ok := l.keyToElem[key]; !ok {
+	elem, ok := l.keyToElem[key]
+	if !ok {
+		l.miss(key)
+		var zeroValue V
+		return zeroValue, ErrKeyNotFound
+	}
+	if l.expired(elem.Value) {
+		l.removeNode(key)
+		l.miss(key)
 		var zeroValue V
 		return zeroValue, ErrKeyNotFound
 	}
 	l.incrementFreq(key)
-	return l.keyToElem[key].Value.value, nil
+	value := l.keyToElem[key].Value.value
+	l.hits++
+	if l.onHit != nil {
+		l.onHit(key, value)
+	}
+	return value, nil
+}
+
+func (l *cacheImpl[K, V]) miss(key K) {
+	l.misses++
+	if l.onMiss != nil {
+		l.onMiss(key)
+	}
 }
 
 // Remove least frequently used item from cache
 func (l *cacheImpl[K, V]) extractLatest() {
 	list := l.freqToElem[l.minFreq]
 	elem := list.Value.Remove(list.Value.Back())
+	if l.onEvict != nil {
+		l.onEvict(elem.key, elem.value, elem.freq)
+	}
 	delete(l.keyToElem, elem.key)
+	l.evictions++
 }
 
 func (l *cacheImpl[K, V]) Put(key K, value V) {
+	l.put(key, value, l.defaultTTL)
+}
+
+func (l *cacheImpl[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	l.put(key, value, ttl)
+}
+
+func (l *cacheImpl[K, V]) put(key K, value V, ttl time.Duration) {
 	if l.capacity == 0 {
 		return
 	}
 
-	// if an element exists, we must increase its frequency
-	if elem, ok := l.keyToElem[key]; ok {
+	l.maybeRescore()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	// if a live element exists, we must increase its frequency; an
+	// expired one falls through to the create-new-node path below so its
+	// frequency resets to 1 instead of counting as a hit
+	if elem, ok := l.keyToElem[key]; ok && !l.expired(elem.Value) {
 		elem.Value.value = value
+		elem.Value.expiresAt = expiresAt
 		l.incrementFreq(key)
 		return
+	} else if ok {
+		l.removeNode(key)
 	}
 
 	if l.Size() == l.capacity {
@@ -149,9 +353,10 @@ func (l *cacheImpl[K, V]) Put(key K, value V) {
 	// Create new node
 	l.minFreq = 1
 	n := node[K, V]{
-		key:   key,
-		value: value,
-		freq:  l.minFreq,
+		key:       key,
+		value:     value,
+		freq:      l.minFreq,
+		expiresAt: expiresAt,
 	}
 
 	// Check if there is a node with the next frequency in the list
@@ -162,15 +367,43 @@ func (l *cacheImpl[K, V]) Put(key K, value V) {
 	l.keyToElem[key] = l.freqToElem[l.minFreq].Value.PushFront(n)
 }
 
+// snapshotEntries walks the cache in descending-frequency,
+// most-recently-used-first order and returns every live entry,
+// sweeping out any expired ones it finds along the way. It's the shared
+// core of All (which discards Freq) and NewSharded's merge (which needs
+// it).
+func (l *cacheImpl[K, V]) snapshotEntries() []Entry[K, V] {
+	var expiredKeys []K
+	entries := make([]Entry[K, V], 0, len(l.keyToElem))
+
+	iter1 := l.freqToList.NewIteratorPrev()
+	for nodeFreq, ok := iter1(); ok; nodeFreq, ok = iter1() {
+		iter2 := nodeFreq.Value.NewIteratorNext()
+		for node, skp := iter2(); skp; node, skp = iter2() {
+			if l.expired(node.Value) {
+				expiredKeys = append(expiredKeys, node.Value.key)
+				continue
+			}
+			entries = append(entries, Entry[K, V]{Key: node.Value.key, Value: node.Value.value, Freq: node.Value.freq})
+		}
+	}
+
+	for _, key := range expiredKeys {
+		l.removeNode(key)
+	}
+	return entries
+}
+
+// All returns the iterator in descending order of frequency. Expired
+// entries are treated as absent: they're skipped and swept out of
+// freqToList/freqToElem/keyToElem before All returns, rather than left
+// to linger until their next direct access.
 func (l *cacheImpl[K, V]) All() iter.Seq2[K, V] {
+	entries := l.snapshotEntries()
 	return func(yield func(K, V) bool) {
-		iter1 := l.freqToList.NewIteratorPrev()
-		for nodeFreq, ok := iter1(); ok; nodeFreq, ok = iter1() {
-			iter2 := nodeFreq.Value.NewIteratorNext()
-			for node, skp := iter2(); skp; node, skp = iter2() {
-				if !yield(node.Value.key, node.Value.value) {
-					return
-				}
+		for _, e := range entries {
+			if !yield(e.Key, e.Value) {
+				return
 			}
 		}
 	}
@@ -0,0 +1,28 @@
+package lfu
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPutExpiredKeyResetsFrequency exercises Put against a key whose TTL
+// already elapsed but that no Get/All/janitor sweep has touched yet. Put
+// must treat it as a fresh insert (frequency reset to 1), not as a live
+// hit (frequency incremented), or a cold key that keeps getting
+// overwritten via Put alone builds an immortal high-frequency zombie
+// entry.
+func TestPutExpiredKeyResetsFrequency(t *testing.T) {
+	c := New[string, int](2)
+	c.PutWithTTL("a", 1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	c.Put("a", 2)
+
+	freq, err := c.GetKeyFrequency("a")
+	if err != nil {
+		t.Fatalf("GetKeyFrequency returned error: %v", err)
+	}
+	if freq != 1 {
+		t.Fatalf("expected frequency 1 for a re-inserted expired key, got %d", freq)
+	}
+}
@@ -0,0 +1,47 @@
+package lfu
+
+// Stats summarizes a cache's hit/miss/eviction counts since creation,
+// useful for exporting to Prometheus or asserting on in tests.
+type Stats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+	MinFreq   int
+}
+
+// WithOnEvict registers a callback fired with the key, value and
+// frequency of every entry evicted to make room for a new one. Without
+// it, extractLatest silently discards the value with no way to flush it
+// to a backing store or log it.
+func WithOnEvict[K comparable, V any](fn func(K, V, int)) Option[K, V] {
+	return func(c *cacheImpl[K, V]) {
+		c.onEvict = fn
+	}
+}
+
+// WithOnHit registers a callback fired on every Get that finds a
+// live (non-expired) key.
+func WithOnHit[K comparable, V any](fn func(K, V)) Option[K, V] {
+	return func(c *cacheImpl[K, V]) {
+		c.onHit = fn
+	}
+}
+
+// WithOnMiss registers a callback fired on every Get that finds no key,
+// including one that existed but had expired.
+func WithOnMiss[K comparable, V any](fn func(K)) Option[K, V] {
+	return func(c *cacheImpl[K, V]) {
+		c.onMiss = fn
+	}
+}
+
+// Stats returns the cache's current hit, miss and eviction counts,
+// along with its current minimum frequency.
+func (l *cacheImpl[K, V]) Stats() Stats {
+	return Stats{
+		Hits:      l.hits,
+		Misses:    l.misses,
+		Evictions: l.evictions,
+		MinFreq:   l.minFreq,
+	}
+}
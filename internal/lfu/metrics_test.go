@@ -0,0 +1,90 @@
+package lfu
+
+import "testing"
+
+// TestOnEvictFires guards the actual point of WithOnEvict: that it fires
+// with the evicted entry's key, value and frequency, not just that Stats
+// counts the eviction.
+func TestOnEvictFires(t *testing.T) {
+	var gotKey, gotValue, gotFreq = "", 0, 0
+	c := New[string, int](1, WithOnEvict[string, int](func(k string, v int, freq int) {
+		gotKey, gotValue, gotFreq = k, v, freq
+	}))
+
+	c.Put("a", 1)
+	c.Get("a") // bump a to freq 2 so we can tell it apart from a fresh insert
+	c.Put("b", 2)
+
+	if gotKey != "a" || gotValue != 1 || gotFreq != 2 {
+		t.Fatalf("onEvict got (%q, %d, %d), want (\"a\", 1, 2)", gotKey, gotValue, gotFreq)
+	}
+}
+
+// TestOnHitOnMissFire guards that WithOnHit/WithOnMiss fire on the right
+// Get outcome, not just that Stats' hit/miss counters increment.
+func TestOnHitOnMissFire(t *testing.T) {
+	var hitKey string
+	var hitValue int
+	var missKey string
+	c := New[string, int](4,
+		WithOnHit[string, int](func(k string, v int) { hitKey, hitValue = k, v }),
+		WithOnMiss[string, int](func(k string) { missKey = k }),
+	)
+
+	c.Put("a", 1)
+	if _, err := c.Get("a"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if hitKey != "a" || hitValue != 1 {
+		t.Fatalf("onHit got (%q, %d), want (\"a\", 1)", hitKey, hitValue)
+	}
+
+	if _, err := c.Get("missing"); err == nil {
+		t.Fatal("expected ErrKeyNotFound for missing key")
+	}
+	if missKey != "missing" {
+		t.Fatalf("onMiss got %q, want \"missing\"", missKey)
+	}
+}
+
+// TestSyncCacheStatsForwarding guards against Stats being stranded on
+// cacheImpl: NewConcurrent's returned Cache used to have no way to reach
+// it, not even via type assertion, since it was never forwarded.
+func TestSyncCacheStatsForwarding(t *testing.T) {
+	c := NewConcurrent[string, int](4).(*syncCache[string, int])
+
+	c.Put("a", 1)
+	if _, err := c.Get("a"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if _, err := c.Get("missing"); err == nil {
+		t.Fatal("expected ErrKeyNotFound for missing key")
+	}
+
+	st := c.Stats()
+	if st.Hits != 1 || st.Misses != 1 {
+		t.Fatalf("got %+v, want Hits=1, Misses=1", st)
+	}
+}
+
+// TestShardedCacheStatsAggregation guards against Stats being stranded
+// on a shardedCache's individual shards: it must aggregate hits, misses
+// and evictions across all of them, not just the shard a caller happens
+// to reach through type assertion.
+func TestShardedCacheStatsAggregation(t *testing.T) {
+	c := NewSharded[string, int](4, 2, shardByFirstByte(2)).(*shardedCache[string, int])
+
+	c.Put("a-low", 1)
+	c.Put("b-high", 2)
+	if _, err := c.Get("a-low"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if _, err := c.Get("missing"); err == nil {
+		t.Fatal("expected ErrKeyNotFound for missing key")
+	}
+
+	st := c.Stats()
+	if st.Hits != 1 || st.Misses != 1 {
+		t.Fatalf("got %+v, want Hits=1, Misses=1 summed across shards", st)
+	}
+}
@@ -0,0 +1,184 @@
+package lfu
+
+import (
+	"container/heap"
+	"hash/maphash"
+	"iter"
+	"time"
+)
+
+// StringHasher returns a default hasher for NewSharded when K is
+// string, backed by maphash.
+func StringHasher() func(string) uint64 {
+	seed := maphash.MakeSeed()
+	return func(s string) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		h.WriteString(s)
+		return h.Sum64()
+	}
+}
+
+// IntHasher returns a default hasher for NewSharded when K is int,
+// backed by maphash over the integer's bytes. Callers with other key
+// types must supply their own hasher.
+func IntHasher() func(int) uint64 {
+	seed := maphash.MakeSeed()
+	return func(n int) uint64 {
+		var buf [8]byte
+		for i := range buf {
+			buf[i] = byte(n >> (8 * i))
+		}
+		var h maphash.Hash
+		h.SetSeed(seed)
+		h.Write(buf[:])
+		return h.Sum64()
+	}
+}
+
+// shardedCache spreads keys across shardCount independent cacheImpl
+// shards, each guarded by its own mutex via syncCache, so Put-heavy
+// traffic isn't serialized behind the single mutex NewConcurrent uses.
+type shardedCache[K comparable, V any] struct {
+	shards []*syncCache[K, V]
+	hasher func(K) uint64
+}
+
+// NewSharded initializes a sharded LFU cache of shardCount shards, each
+// with capacity totalCapacity/shardCount, dispatching Get/Put/
+// GetKeyFrequency by hasher(key) % shardCount. Use StringHasher or
+// IntHasher for string/int keys, or supply your own for other
+// comparable key types.
+func NewSharded[K comparable, V any](totalCapacity, shardCount int, hasher func(K) uint64) Cache[K, V] {
+	if shardCount <= 0 {
+		panic("shardCount must be a positive integer")
+	}
+	shards := make([]*syncCache[K, V], shardCount)
+	for i := range shards {
+		shards[i] = &syncCache[K, V]{cache: New[K, V](totalCapacity / shardCount)}
+	}
+	return &shardedCache[K, V]{shards: shards, hasher: hasher}
+}
+
+func (s *shardedCache[K, V]) shardFor(key K) *syncCache[K, V] {
+	return s.shards[s.hasher(key)%uint64(len(s.shards))]
+}
+
+func (s *shardedCache[K, V]) Get(key K) (V, error) {
+	return s.shardFor(key).Get(key)
+}
+
+func (s *shardedCache[K, V]) Put(key K, value V) {
+	s.shardFor(key).Put(key, value)
+}
+
+func (s *shardedCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	s.shardFor(key).PutWithTTL(key, value, ttl)
+}
+
+func (s *shardedCache[K, V]) GetKeyFrequency(key K) (int, error) {
+	return s.shardFor(key).GetKeyFrequency(key)
+}
+
+func (s *shardedCache[K, V]) Size() int {
+	total := 0
+	for _, shard := range s.shards {
+		total += shard.Size()
+	}
+	return total
+}
+
+func (s *shardedCache[K, V]) Capacity() int {
+	total := 0
+	for _, shard := range s.shards {
+		total += shard.Capacity()
+	}
+	return total
+}
+
+// Stats aggregates hit, miss and eviction counts across every shard, and
+// reports the lowest MinFreq among them as the sharded cache's overall
+// minimum frequency.
+func (s *shardedCache[K, V]) Stats() Stats {
+	var total Stats
+	for i, shard := range s.shards {
+		st := shard.Stats()
+		total.Hits += st.Hits
+		total.Misses += st.Misses
+		total.Evictions += st.Evictions
+		if i == 0 || st.MinFreq < total.MinFreq {
+			total.MinFreq = st.MinFreq
+		}
+	}
+	return total
+}
+
+func (s *shardedCache[K, V]) Close() error {
+	for _, shard := range s.shards {
+		if err := shard.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shardHead is one shard's current position during the All() merge, into
+// a snapshot taken up front by allEntries - entry.Freq is carried straight
+// out of that snapshot rather than re-derived from live state, so a
+// mutation racing the merge can't invert the frequency order it sorts by.
+type shardHead[K comparable, V any] struct {
+	entry Entry[K, V]
+	shard int
+	pos   int
+}
+
+// headHeap orders shardHeads by descending frequency, the same contract
+// All() documents for a single cache.
+type headHeap[K comparable, V any] []*shardHead[K, V]
+
+func (h headHeap[K, V]) Len() int           { return len(h) }
+func (h headHeap[K, V]) Less(i, j int) bool { return h[i].entry.Freq > h[j].entry.Freq }
+func (h headHeap[K, V]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *headHeap[K, V]) Push(x any)        { *h = append(*h, x.(*shardHead[K, V])) }
+func (h *headHeap[K, V]) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// All merges every shard's entries in descending-frequency order using a
+// small heap over the shards' current positions, so descending frequency
+// holds across shards. Recency order among equal frequencies holds only
+// within one shard; equal-frequency entries from different shards come
+// out in no defined order relative to each other. Each shard is
+// snapshotted up front via allEntries, under that shard's own lock, so
+// the frequency the merge sorts by always matches the value it was
+// paired with at snapshot time.
+func (s *shardedCache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		shardEntries := make([][]Entry[K, V], len(s.shards))
+		for i, shard := range s.shards {
+			shardEntries[i] = shard.allEntries()
+		}
+
+		h := make(headHeap[K, V], 0, len(s.shards))
+		for i, entries := range shardEntries {
+			if len(entries) > 0 {
+				h = append(h, &shardHead[K, V]{entry: entries[0], shard: i, pos: 0})
+			}
+		}
+		heap.Init(&h)
+
+		for h.Len() > 0 {
+			head := heap.Pop(&h).(*shardHead[K, V])
+			if !yield(head.entry.Key, head.entry.Value) {
+				return
+			}
+			if pos := head.pos + 1; pos < len(shardEntries[head.shard]) {
+				heap.Push(&h, &shardHead[K, V]{entry: shardEntries[head.shard][pos], shard: head.shard, pos: pos})
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package lfu
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	"lfucache/internal/linkedlist"
+)
+
+// Entry is a single key/value/frequency record produced by Snapshot and
+// consumed by Restore, letting a cache's state survive a process
+// restart instead of paying a full cold-cache penalty on every deploy.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+	Freq  int
+}
+
+// Snapshot captures the cache's current, non-expired entries in
+// ascending-frequency, oldest-within-bucket-first order - exactly the
+// order Restore expects.
+//
+// O(capacity)
+func (l *cacheImpl[K, V]) Snapshot() ([]Entry[K, V], error) {
+	entries := make([]Entry[K, V], 0, len(l.keyToElem))
+
+	iter1 := l.freqToList.NewIteratorNext() // ascending frequency
+	for freqElem, ok := iter1(); ok; freqElem, ok = iter1() {
+		iter2 := freqElem.Value.NewIteratorPrev() // oldest to most recently used
+		for n, ok2 := iter2(); ok2; n, ok2 = iter2() {
+			if l.expired(n.Value) {
+				continue
+			}
+			entries = append(entries, Entry[K, V]{Key: n.Value.key, Value: n.Value.value, Freq: n.Value.freq})
+		}
+	}
+	return entries, nil
+}
+
+// Restore replaces the cache's contents with entries, rebuilding
+// freqToList/freqToElem/keyToElem bucket by bucket so minFreq and
+// intra-bucket recency order come out the same as they were when
+// Snapshot produced entries. entries must already be in
+// non-decreasing-frequency order, as Snapshot guarantees; Restore
+// rejects anything else rather than silently building a cache whose
+// All() no longer returns entries in descending-frequency order.
+//
+// O(capacity)
+func (l *cacheImpl[K, V]) Restore(entries []Entry[K, V]) error {
+	if len(entries) > l.capacity {
+		return fmt.Errorf("lfu: restore: %d entries exceed capacity %d", len(entries), l.capacity)
+	}
+
+	freqToList := linkedlist.NewList[linkedlist.ListInterface[node[K, V]]]()
+	freqToElem := make(map[int]*linkedlist.Element[linkedlist.ListInterface[node[K, V]]], l.capacity)
+	keyToElem := make(map[K]*linkedlist.Element[node[K, V]], l.capacity)
+	minFreq := 0
+	lastFreq := 0
+
+	for _, e := range entries {
+		if e.Freq <= 0 {
+			return fmt.Errorf("lfu: restore: invalid frequency %d for key %v", e.Freq, e.Key)
+		}
+		if e.Freq < lastFreq {
+			return fmt.Errorf("lfu: restore: entries must be in non-decreasing frequency order, got freq %d after %d", e.Freq, lastFreq)
+		}
+		lastFreq = e.Freq
+
+		list, ok := freqToElem[e.Freq]
+		if !ok {
+			bucket := linkedlist.NewList[node[K, V]]()
+			list = freqToList.PushBack(bucket)
+			freqToElem[e.Freq] = list
+		}
+		n := node[K, V]{key: e.Key, value: e.Value, freq: e.Freq}
+		keyToElem[e.Key] = list.Value.PushFront(n)
+		if minFreq == 0 || e.Freq < minFreq {
+			minFreq = e.Freq
+		}
+	}
+
+	l.freqToList = freqToList
+	l.freqToElem = freqToElem
+	l.keyToElem = keyToElem
+	l.minFreq = minFreq
+	return nil
+}
+
+// MarshalBinary encodes the cache's current contents with encoding/gob
+// so they can be written to disk and hydrated on the next boot.
+func (l *cacheImpl[K, V]) MarshalBinary() ([]byte, error) {
+	entries, err := l.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(entries); err != nil {
+		return nil, fmt.Errorf("lfu: marshal: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary and replaces
+// the cache's contents with it. The cache must already exist (built via
+// New) since capacity isn't part of the encoded payload.
+func (l *cacheImpl[K, V]) UnmarshalBinary(data []byte) error {
+	var entries []Entry[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entries); err != nil {
+		return fmt.Errorf("lfu: unmarshal: %w", err)
+	}
+	return l.Restore(entries)
+}
@@ -0,0 +1,56 @@
+package lfu
+
+import "testing"
+
+// TestRestoreRejectsOutOfOrderEntries guards Restore's ascending-frequency
+// invariant: entries in descending order used to silently build a cache
+// whose All() broke the descending-frequency contract instead of erroring.
+func TestRestoreRejectsOutOfOrderEntries(t *testing.T) {
+	c := New[string, int](4)
+	err := c.Restore([]Entry[string, int]{
+		{Key: "hot", Value: 1, Freq: 5},
+		{Key: "cold", Value: 2, Freq: 1},
+	})
+	if err == nil {
+		t.Fatal("expected an error for out-of-order entries, got nil")
+	}
+}
+
+// TestRestoreRejectsTooManyEntries guards the capacity invariant: Size()
+// must never exceed capacity, or put's eviction check (Size() == capacity)
+// never fires again and the cache grows unbounded on every new-key Put.
+func TestRestoreRejectsTooManyEntries(t *testing.T) {
+	c := New[string, int](1)
+	err := c.Restore([]Entry[string, int]{
+		{Key: "a", Value: 1, Freq: 1},
+		{Key: "b", Value: 2, Freq: 1},
+	})
+	if err == nil {
+		t.Fatal("expected an error for entries exceeding capacity, got nil")
+	}
+}
+
+// TestSnapshotRestoreRoundTrip checks that Snapshot's own output, fed
+// straight back into Restore, reproduces the same All() order.
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+	c := New[string, int](4)
+	c.Put("A", 1)
+	c.Put("B", 2)
+	c.Get("A") // bump A to freq 2
+
+	snap, err := c.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+
+	restored := New[string, int](4)
+	if err := restored.Restore(snap); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	want := keys(c.All())
+	got := keys(restored.All())
+	if !equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}